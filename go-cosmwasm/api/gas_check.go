@@ -0,0 +1,148 @@
+// +build !secretcli
+
+package api
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrEnclaveGasMismatch is returned when the gas the enclave reports using for a call
+// falls below the Go side's independently-computed expected gas floor by more than the
+// configured tolerance. This guards against a compromised or buggy enclave under-reporting
+// gas, the same way go-ethereum's tracers cross-check EVM host/guest gas. It's a static
+// lower bound built from the wasm module's code size, not a dynamic execution trace, so a
+// contract taking loops/branches is expected to use *more* gas than this floor - only
+// under-reporting below it is meaningful. When gasMismatchEnforced is set, a mismatch fails
+// the call with this error instead of just being logged, so callers must treat it as a
+// rejection, not merely an alert.
+var ErrEnclaveGasMismatch = errors.New("enclave gas mismatch")
+
+// DefaultGasMismatchTolerance is the fraction (0-1) the enclave-reported gasUsed is allowed
+// to fall below the Go-side envelope floor before it's flagged as a mismatch.
+const DefaultGasMismatchTolerance = 0.2
+
+// gasPerInstruction and gasPerKVByte are coarse stand-ins for the enclave's CosmWasm gas
+// schedule - close enough to build a lower bound on expected gas without re-implementing
+// the schedule here.
+const (
+	gasPerInstruction uint64 = 1
+	gasPerKVByte      uint64 = 1
+)
+
+var gasMismatchTolerance = DefaultGasMismatchTolerance
+
+// gasMismatchEnforced controls whether a mismatch actually fails the call
+// (ErrEnclaveGasMismatch returned to the caller) or is only logged. It defaults to true so
+// the envelope behaves as the integrity guard it's meant to be; operators who hit false
+// positives before the tolerance can be tuned can flip it off with SetGasMismatchEnforced
+// rather than losing the check's output entirely.
+var gasMismatchEnforced = true
+
+// SetGasMismatchTolerance overrides how far the enclave-reported gasUsed may fall below
+// the Go-side envelope floor before it's flagged with ErrEnclaveGasMismatch.
+func SetGasMismatchTolerance(tolerance float64) { gasMismatchTolerance = tolerance }
+
+// SetGasMismatchEnforced controls whether a flagged mismatch rejects the call
+// (ErrEnclaveGasMismatch propagated to the caller) or is only logged via logGasMismatch.
+func SetGasMismatchEnforced(enforced bool) { gasMismatchEnforced = enforced }
+
+// GasEnvelope is the Go side's independent estimate of the minimum gas a call should have
+// consumed, built from the wasm module's instruction count and the byte length of the
+// message parts (params/msg/result) that crossed the FFI boundary for the call. KVBytes is
+// named for the KV traffic it's meant to approximate, but this layer has no access to the
+// actual per-call KV read/write byte counts - store.KVStore/buildDB aren't instrumented for
+// it - so it's built from call payload sizes as a proxy instead. That keeps the floor cheap
+// to compute but means it tracks message size, not real storage work; a contract that does
+// a lot of KV I/O on a tiny message is undercounted here.
+type GasEnvelope struct {
+	InstructionCount uint64
+	KVBytes          uint64
+}
+
+// CheckGasEnvelope compares the enclave-reported gasUsed against envelope's static floor
+// and returns ErrEnclaveGasMismatch if gasUsed falls more than tolerance below it. Usage
+// above the floor is expected (loops and branches only ever add to it) and never flagged.
+func CheckGasEnvelope(envelope GasEnvelope, gasUsed uint64, tolerance float64) error {
+	expected := envelope.InstructionCount*gasPerInstruction + envelope.KVBytes*gasPerKVByte
+	if expected == 0 {
+		return nil
+	}
+
+	floor := uint64(float64(expected) * (1 - tolerance))
+	if gasUsed < floor {
+		return fmt.Errorf("%w: enclave reported %d gas, below the static floor of ~%d (-%.0f%%)", ErrEnclaveGasMismatch, gasUsed, expected, tolerance*100)
+	}
+
+	return nil
+}
+
+var (
+	instructionCountMu    sync.Mutex
+	instructionCountCache = map[string]uint64{}
+)
+
+// instructionCountForCode returns the (cached) wasm instruction count for codeID, fetching
+// and walking the code the first time it's asked for.
+func instructionCountForCode(cache Cache, codeID []byte) (uint64, error) {
+	key := string(codeID)
+
+	instructionCountMu.Lock()
+	n, ok := instructionCountCache[key]
+	instructionCountMu.Unlock()
+	if ok {
+		return n, nil
+	}
+
+	wasm, err := GetCode(cache, codeID)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err = countWasmInstructions(wasm)
+	if err != nil {
+		return 0, err
+	}
+
+	instructionCountMu.Lock()
+	instructionCountCache[key] = n
+	instructionCountMu.Unlock()
+
+	return n, nil
+}
+
+// verifyGasEnvelope builds a GasEnvelope for codeID from its cached instruction count plus
+// the byte length of every message part passed in (see GasEnvelope.KVBytes for why that's
+// an approximation of real KV traffic rather than the traffic itself), then checks it
+// against gasUsed. Envelope construction failures (e.g. the code isn't cached yet) never
+// block the call - an envelope we couldn't build isn't evidence of anything - but a mismatch
+// against one we could build is handled by the caller per gasMismatchEnforced.
+func verifyGasEnvelope(cache Cache, codeID []byte, gasUsed uint64, msgParts ...[]byte) error {
+	instrCount, err := instructionCountForCode(cache, codeID)
+	if err != nil {
+		return nil
+	}
+
+	var kvBytes uint64
+	for _, part := range msgParts {
+		kvBytes += uint64(len(part))
+	}
+
+	return CheckGasEnvelope(GasEnvelope{InstructionCount: instrCount, KVBytes: kvBytes}, gasUsed, gasMismatchTolerance)
+}
+
+// logGasMismatch reports a gas envelope mismatch through the same structured enclave
+// logger wired up for FFI call tracing. It always runs on a flagged mismatch (one beyond the
+// configured tolerance), independent of gasMismatchEnforced, so operators keep a record of
+// flagged calls even when enforcement is tuned off. Shortfalls within the tolerance aren't
+// flagged and so aren't logged.
+func logGasMismatch(call string, codeID []byte, gasUsed uint64, gasErr error) {
+	enclaveLogger.LogEnclaveCall(EnclaveCallFields{
+		Call:       call,
+		CodeID:     hex.EncodeToString(codeID),
+		GasUsed:    gasUsed,
+		ErrorClass: gasErr.Error(),
+	})
+}
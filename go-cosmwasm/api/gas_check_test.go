@@ -0,0 +1,125 @@
+// +build !secretcli
+
+package api
+
+import "testing"
+
+// buildWasmModule assembles a minimal wasm module containing a single code section with
+// one function body per entry in bodies. Bodies and the module itself are kept under 128
+// bytes so their LEB128 varuint length prefixes fit in a single byte.
+func buildWasmModule(bodies [][]byte) []byte {
+	var code []byte
+	code = append(code, byte(len(bodies)))
+	for _, body := range bodies {
+		code = append(code, byte(len(body)))
+		code = append(code, body...)
+	}
+
+	module := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	module = append(module, wasmCodeSectionID, byte(len(code)))
+	module = append(module, code...)
+	return module
+}
+
+func TestCountWasmInstructions(t *testing.T) {
+	tests := []struct {
+		name   string
+		bodies [][]byte
+		want   uint64
+	}{
+		{"straight-line body", [][]byte{{1, 2, 3, 4, 5}}, 5},
+		// A handful of bytes encoding a loop - real execution may run this thousands of
+		// times, but the static walker only ever sees the 7 bytes of bytecode.
+		{"loop body", [][]byte{{0x03, 0x40, 0x41, 0x01, 0x0c, 0x00, 0x0b}}, 7},
+		{"multiple functions", [][]byte{{1, 2, 3}, {4, 5}}, 5},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := countWasmInstructions(buildWasmModule(tc.bodies))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("got %d instructions, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCountWasmInstructions_InvalidMagic(t *testing.T) {
+	if _, err := countWasmInstructions([]byte{1, 2, 3}); err == nil {
+		t.Fatal("expected an error for a non-wasm input")
+	}
+}
+
+func TestGasMismatchEnforcedDefaultsTrue(t *testing.T) {
+	if !gasMismatchEnforced {
+		t.Fatal("gasMismatchEnforced must default to true so a flagged mismatch rejects the call")
+	}
+}
+
+func TestSetGasMismatchEnforced(t *testing.T) {
+	defer SetGasMismatchEnforced(gasMismatchEnforced)
+
+	SetGasMismatchEnforced(false)
+	if gasMismatchEnforced {
+		t.Fatal("SetGasMismatchEnforced(false) did not take effect")
+	}
+
+	SetGasMismatchEnforced(true)
+	if !gasMismatchEnforced {
+		t.Fatal("SetGasMismatchEnforced(true) did not take effect")
+	}
+}
+
+func TestCheckGasEnvelope(t *testing.T) {
+	tests := []struct {
+		name      string
+		envelope  GasEnvelope
+		gasUsed   uint64
+		tolerance float64
+		wantErr   bool
+	}{
+		{
+			name:      "looped contract uses far more than its static floor",
+			envelope:  GasEnvelope{InstructionCount: 7, KVBytes: 10},
+			gasUsed:   50_000,
+			tolerance: 0.2,
+			wantErr:   false,
+		},
+		{
+			name:      "gas used exactly at the floor",
+			envelope:  GasEnvelope{InstructionCount: 100},
+			gasUsed:   80,
+			tolerance: 0.2,
+			wantErr:   false,
+		},
+		{
+			name:      "enclave under-reports gas below the floor",
+			envelope:  GasEnvelope{InstructionCount: 100},
+			gasUsed:   10,
+			tolerance: 0.2,
+			wantErr:   true,
+		},
+		{
+			name:      "zero envelope never flags",
+			envelope:  GasEnvelope{},
+			gasUsed:   0,
+			tolerance: 0.2,
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckGasEnvelope(tc.envelope, tc.gasUsed, tc.tolerance)
+			if tc.wantErr && err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -7,8 +7,10 @@ package api
 import "C"
 
 import (
+	"encoding/hex"
 	"fmt"
 	"syscall"
+	"time"
 
 	"github.com/enigmampc/SecretNetwork/go-cosmwasm/types"
 )
@@ -27,6 +29,34 @@ type Cache struct {
 	ptr *C.cache_t
 }
 
+// traceEnclaveCall starts a logger/tracer span for an enclave FFI call and returns a
+// closure to end it with the call's outcome - the gas the enclave reported used and
+// whatever error (if any) the C call returned.
+func traceEnclaveCall(call string, codeID []byte, gasLimit uint64) func(gasUsed uint64, err error) {
+	span := enclaveTracer.StartSpan(call)
+	start := time.Now()
+
+	return func(gasUsed uint64, err error) {
+		fields := EnclaveCallFields{
+			Call:     call,
+			CodeID:   hex.EncodeToString(codeID),
+			GasLimit: gasLimit,
+			GasUsed:  gasUsed,
+			Duration: time.Since(start),
+		}
+
+		if err != nil {
+			if errno, ok := err.(syscall.Errno); ok {
+				fields.Errno = int(errno)
+			}
+			fields.ErrorClass = "enclave_error"
+		}
+
+		span.End(fields)
+		enclaveLogger.LogEnclaveCall(fields)
+	}
+}
+
 func HealthCheck() ([]byte, error) {
 	errmsg := C.Buffer{}
 
@@ -52,9 +82,11 @@ func LoadSeedToEnclave(masterCert []byte, seed []byte) (bool, error) {
 	defer freeAfterSend(pkSlice)
 	seedSlice := sendSlice(seed)
 	defer freeAfterSend(seedSlice)
+	done := traceEnclaveCall("load_seed_to_enclave", nil, 0)
 	errmsg := C.Buffer{}
 
 	_, err := C.init_node(pkSlice, seedSlice, &errmsg)
+	done(0, err)
 	if err != nil {
 		return false, errorWithMessage(err, errmsg)
 	}
@@ -127,14 +159,24 @@ func Instantiate(
 	a := buildAPI(api)
 	q := buildQuerier(querier)
 	var gasUsed u64
+	done := traceEnclaveCall("instantiate", code_id, gasLimit)
 
 	errmsg := C.Buffer{}
 	res, err := C.instantiate(cache.ptr, id, p, m, db, a, q, u64(gasLimit), &gasUsed, &errmsg, s)
+	done(uint64(gasUsed), err)
 	if err != nil && err.(syscall.Errno) != C.ErrnoValue_Success {
 		// Depending on the nature of the error, `gasUsed` will either have a meaningful value, or just 0.
 		return nil, uint64(gasUsed), errorWithMessage(err, errmsg)
 	}
-	return receiveVector(res), uint64(gasUsed), nil
+
+	result := receiveVector(res)
+	if gasErr := verifyGasEnvelope(cache, code_id, uint64(gasUsed), params, msg, result); gasErr != nil {
+		logGasMismatch("instantiate", code_id, uint64(gasUsed), gasErr)
+		if gasMismatchEnforced {
+			return nil, uint64(gasUsed), gasErr
+		}
+	}
+	return result, uint64(gasUsed), nil
 }
 
 func Handle(
@@ -161,13 +203,23 @@ func Handle(
 	a := buildAPI(api)
 	q := buildQuerier(querier)
 	var gasUsed u64
+	done := traceEnclaveCall("handle", code_id, gasLimit)
 	errmsg := C.Buffer{}
 	res, err := C.handle(cache.ptr, id, p, m, db, a, q, u64(gasLimit), &gasUsed, &errmsg, s)
+	done(uint64(gasUsed), err)
 	if err != nil && err.(syscall.Errno) != C.ErrnoValue_Success {
 		// Depending on the nature of the error, `gasUsed` will either have a meaningful value, or just 0.
 		return nil, uint64(gasUsed), errorWithMessage(err, errmsg)
 	}
-	return receiveVector(res), uint64(gasUsed), nil
+
+	result := receiveVector(res)
+	if gasErr := verifyGasEnvelope(cache, code_id, uint64(gasUsed), params, msg, result); gasErr != nil {
+		logGasMismatch("handle", code_id, uint64(gasUsed), gasErr)
+		if gasMismatchEnforced {
+			return nil, uint64(gasUsed), gasErr
+		}
+	}
+	return result, uint64(gasUsed), nil
 }
 
 func Migrate(
@@ -191,13 +243,23 @@ func Migrate(
 	a := buildAPI(api)
 	q := buildQuerier(querier)
 	var gasUsed u64
+	done := traceEnclaveCall("migrate", code_id, gasLimit)
 	errmsg := C.Buffer{}
 	res, err := C.migrate(cache.ptr, id, p, m, db, a, q, u64(gasLimit), &gasUsed, &errmsg)
+	done(uint64(gasUsed), err)
 	if err != nil && err.(syscall.Errno) != C.ErrnoValue_Success {
 		// Depending on the nature of the error, `gasUsed` will either have a meaningful value, or just 0.
 		return nil, uint64(gasUsed), errorWithMessage(err, errmsg)
 	}
-	return receiveVector(res), uint64(gasUsed), nil
+
+	result := receiveVector(res)
+	if gasErr := verifyGasEnvelope(cache, code_id, uint64(gasUsed), params, msg, result); gasErr != nil {
+		logGasMismatch("migrate", code_id, uint64(gasUsed), gasErr)
+		if gasMismatchEnforced {
+			return nil, uint64(gasUsed), gasErr
+		}
+	}
+	return result, uint64(gasUsed), nil
 }
 
 func Query(
@@ -218,13 +280,23 @@ func Query(
 	a := buildAPI(api)
 	q := buildQuerier(querier)
 	var gasUsed u64
+	done := traceEnclaveCall("query", code_id, gasLimit)
 	errmsg := C.Buffer{}
 	res, err := C.query(cache.ptr, id, m, db, a, q, u64(gasLimit), &gasUsed, &errmsg)
+	done(uint64(gasUsed), err)
 	if err != nil && err.(syscall.Errno) != C.ErrnoValue_Success {
 		// Depending on the nature of the error, `gasUsed` will either have a meaningful value, or just 0.
 		return nil, uint64(gasUsed), errorWithMessage(err, errmsg)
 	}
-	return receiveVector(res), uint64(gasUsed), nil
+
+	result := receiveVector(res)
+	if gasErr := verifyGasEnvelope(cache, code_id, uint64(gasUsed), msg, result); gasErr != nil {
+		logGasMismatch("query", code_id, uint64(gasUsed), gasErr)
+		if gasMismatchEnforced {
+			return nil, uint64(gasUsed), gasErr
+		}
+	}
+	return result, uint64(gasUsed), nil
 }
 
 // KeyGen Send KeyGen request to enclave
@@ -248,10 +320,12 @@ func CreateAttestationReport() (bool, error) {
 }
 
 func GetEncryptedSeed(cert []byte) ([]byte, error) {
+	done := traceEnclaveCall("get_encrypted_seed", nil, 0)
 	errmsg := C.Buffer{}
 	certSlice := sendSlice(cert)
 	defer freeAfterSend(certSlice)
 	res, err := C.get_encrypted_seed(certSlice, &errmsg)
+	done(0, err)
 	if err != nil {
 		return nil, errorWithMessage(err, errmsg)
 	}
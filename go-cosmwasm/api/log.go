@@ -0,0 +1,95 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// EnclaveCallFields are the structured fields reported around a single enclave FFI call.
+type EnclaveCallFields struct {
+	Call       string        `json:"call"`
+	CodeID     string        `json:"code_id"`
+	GasLimit   uint64        `json:"gas_limit"`
+	GasUsed    uint64        `json:"gas_used"`
+	Duration   time.Duration `json:"duration_ns"`
+	Errno      int           `json:"errno"`
+	ErrorClass string        `json:"error_class,omitempty"`
+}
+
+// EnclaveLogger receives a structured event for every enclave FFI call (Instantiate,
+// Handle, Migrate, Query, GetEncryptedSeed, LoadSeedToEnclave), so operators can aggregate
+// enclave latency and error rates in Prometheus/Loki.
+type EnclaveLogger interface {
+	LogEnclaveCall(fields EnclaveCallFields)
+}
+
+// EnclaveTracer opens an OpenTelemetry-compatible span around an enclave FFI call.
+type EnclaveTracer interface {
+	StartSpan(call string) EnclaveSpan
+}
+
+// EnclaveSpan is a single in-flight span; End reports the call's outcome and closes it.
+type EnclaveSpan interface {
+	End(fields EnclaveCallFields)
+}
+
+// enclaveLogger and enclaveTracer default to no-ops - operators opt in via SetEnclaveLogger
+// / SetEnclaveTracer from their node's startup code.
+var (
+	enclaveLogger EnclaveLogger = NoopLogger{}
+	enclaveTracer EnclaveTracer = NoopTracer{}
+)
+
+// SetEnclaveLogger overrides the package-wide enclave logger.
+func SetEnclaveLogger(l EnclaveLogger) { enclaveLogger = l }
+
+// SetEnclaveTracer overrides the package-wide enclave tracer.
+func SetEnclaveTracer(t EnclaveTracer) { enclaveTracer = t }
+
+// NoopLogger discards every call - the default until an operator wires in a real one.
+type NoopLogger struct{}
+
+func (NoopLogger) LogEnclaveCall(EnclaveCallFields) {}
+
+// NoopTracer starts spans that do nothing - the default until an operator wires in a real one.
+type NoopTracer struct{}
+
+func (NoopTracer) StartSpan(string) EnclaveSpan { return noopSpan{} }
+
+type noopSpan struct{}
+
+func (noopSpan) End(EnclaveCallFields) {}
+
+// StdLogger writes each EnclaveCallFields event as a single line of JSON to an io.Writer,
+// for operators who just want enclave diagnostics in their regular log stream.
+type StdLogger struct {
+	Out *os.File
+}
+
+// NewStdLogger returns a StdLogger writing to stderr.
+func NewStdLogger() StdLogger {
+	return StdLogger{Out: os.Stderr}
+}
+
+func (l StdLogger) LogEnclaveCall(fields EnclaveCallFields) {
+	bz, err := json.Marshal(fields)
+	if err != nil {
+		fmt.Fprintf(l.Out, `{"call":%q,"log_error":%q}`+"\n", fields.Call, err.Error())
+		return
+	}
+	fmt.Fprintln(l.Out, string(bz))
+}
+
+// StdTracer is a minimal stand-in for a real OpenTelemetry exporter: it prints a one-line
+// span summary to stderr when the span ends.
+type StdTracer struct{}
+
+func (StdTracer) StartSpan(call string) EnclaveSpan { return stdSpan{call: call} }
+
+type stdSpan struct{ call string }
+
+func (s stdSpan) End(fields EnclaveCallFields) {
+	fmt.Fprintf(os.Stderr, "enclave span %s: %s\n", s.call, fields.Duration)
+}
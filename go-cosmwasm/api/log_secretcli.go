@@ -0,0 +1,7 @@
+// +build secretcli
+
+package api
+
+// secretcli links against this package without the enclave FFI, so there are no enclave
+// calls to log or trace: EnclaveLogger/EnclaveTracer simply keep their no-op defaults from
+// log.go and SetEnclaveLogger/SetEnclaveTracer are never called.
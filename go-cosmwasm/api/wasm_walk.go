@@ -0,0 +1,105 @@
+// +build !secretcli
+
+package api
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	wasmMagic         = 0x6d736100 // "\0asm"
+	wasmCodeSectionID = 10
+)
+
+// countWasmInstructions is a lightweight, single-pass walker over a wasm module's code
+// section that estimates the number of instructions its function bodies contain. It
+// doesn't decode individual opcodes or their immediates, so the count is an upper-bound
+// estimate rather than an exact one - accurate enough to build an expected gas envelope,
+// not to re-execute the module.
+func countWasmInstructions(wasm []byte) (uint64, error) {
+	if len(wasm) < 8 || binary.LittleEndian.Uint32(wasm[0:4]) != wasmMagic {
+		return 0, fmt.Errorf("not a valid wasm binary")
+	}
+
+	pos := 8
+	var count uint64
+
+	for pos < len(wasm) {
+		sectionID := wasm[pos]
+		pos++
+
+		size, n, err := readVarUint32(wasm[pos:])
+		if err != nil {
+			return 0, err
+		}
+		pos += n
+
+		if pos+int(size) > len(wasm) {
+			return 0, fmt.Errorf("truncated wasm section")
+		}
+
+		if sectionID == wasmCodeSectionID {
+			count += countCodeSectionInstructions(wasm[pos : pos+int(size)])
+		}
+
+		pos += int(size)
+	}
+
+	return count, nil
+}
+
+// readVarUint32 decodes a single LEB128-encoded unsigned varint and returns its value
+// alongside the number of bytes it occupied.
+func readVarUint32(buf []byte) (uint32, int, error) {
+	var result uint32
+	var shift uint
+
+	for i, b := range buf {
+		result |= uint32(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return result, i + 1, nil
+		}
+
+		shift += 7
+		if shift > 35 {
+			break
+		}
+	}
+
+	return 0, 0, fmt.Errorf("invalid LEB128 varuint")
+}
+
+// countCodeSectionInstructions counts the bytes making up each function body in a code
+// section, skipping the leading function-count varint and each body's size header. Every
+// remaining byte is counted as one instruction; this over-counts multi-byte immediates and
+// local declarations, so the result can exceed the bodies' real instruction count but never
+// fall below it. Since CheckGasEnvelope uses the count as a floor, over-counting raises that
+// floor.
+func countCodeSectionInstructions(section []byte) uint64 {
+	var count uint64
+	pos := 0
+
+	funcCount, n, err := readVarUint32(section[pos:])
+	if err != nil {
+		return 0
+	}
+	pos += n
+
+	for i := uint32(0); i < funcCount && pos < len(section); i++ {
+		bodySize, n, err := readVarUint32(section[pos:])
+		if err != nil {
+			break
+		}
+		pos += n
+
+		if pos+int(bodySize) > len(section) {
+			break
+		}
+
+		count += uint64(bodySize)
+		pos += int(bodySize)
+	}
+
+	return count
+}
@@ -0,0 +1,111 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/scrtlabs/SecretNetwork/x/registration/internal/types"
+	ra "github.com/scrtlabs/SecretNetwork/x/registration/remote_attestation"
+)
+
+// AttestationProviderID identifies which remote-attestation scheme verified a
+// RegistrationNodeInfo entry, so mixed-attestation networks can check old registrations
+// against the provider that originally vouched for them.
+type AttestationProviderID string
+
+const (
+	AttestationProviderEPID AttestationProviderID = "epid"
+	AttestationProviderDCAP AttestationProviderID = "dcap"
+	AttestationProviderMock AttestationProviderID = "mock"
+)
+
+// AttestationProvider abstracts the remote-attestation scheme used to validate a node's
+// enclave before it's allowed to join the seed-sharing set. EPIDProvider wraps the legacy
+// Intel IAS flow, DCAPProvider verifies ECDSA quotes against pinned on-chain collateral,
+// and MockProvider lets simulation and test networks skip hardware attestation entirely.
+type AttestationProvider interface {
+	// ID returns the identifier persisted alongside RegistrationNodeInfo.
+	ID() AttestationProviderID
+	// VerifyCertificate checks the certificate/quote and returns the enclave's public key.
+	VerifyCertificate(cert ra.Certificate) ([]byte, error)
+	// Measurement extracts the enclave measurement a certificate/quote attests to, so it
+	// can be checked against the governance-controlled enclave allow-list.
+	Measurement(cert ra.Certificate) (types.EnclaveMeasurement, error)
+}
+
+// EPIDProvider verifies Intel EPID attestation certificates issued via the Intel
+// Attestation Service (IAS), the scheme Secret Network has used since mainnet launch.
+type EPIDProvider struct{}
+
+func (EPIDProvider) ID() AttestationProviderID { return AttestationProviderEPID }
+
+func (EPIDProvider) VerifyCertificate(cert ra.Certificate) ([]byte, error) {
+	return ra.VerifyRaCert(cert)
+}
+
+func (EPIDProvider) Measurement(cert ra.Certificate) (types.EnclaveMeasurement, error) {
+	mrEnclave, mrSigner, prodID, svn, err := ra.ExtractEpidMeasurement(cert)
+	if err != nil {
+		return types.EnclaveMeasurement{}, err
+	}
+	return types.EnclaveMeasurement{MrEnclave: mrEnclave, MrSigner: mrSigner, IsvProdId: prodID, IsvSvn: svn}, nil
+}
+
+// DCAPCollateral is the pinned PCCS collateral bundle DCAP quotes are verified against.
+type DCAPCollateral struct {
+	TcbInfo     []byte
+	QeIdentity  []byte
+	PckCrlChain []byte
+}
+
+// DCAPProvider verifies Intel SGX DCAP (ECDSA) quotes against a PCCS/collateral bundle
+// stored on-chain as genesis params, for enclave hardware that no longer supports EPID.
+type DCAPProvider struct {
+	Collateral DCAPCollateral
+}
+
+func (DCAPProvider) ID() AttestationProviderID { return AttestationProviderDCAP }
+
+func (p DCAPProvider) VerifyCertificate(cert ra.Certificate) ([]byte, error) {
+	return ra.VerifyDcapQuote(cert, p.Collateral.TcbInfo, p.Collateral.QeIdentity, p.Collateral.PckCrlChain)
+}
+
+func (DCAPProvider) Measurement(cert ra.Certificate) (types.EnclaveMeasurement, error) {
+	mrEnclave, mrSigner, prodID, svn, err := ra.ExtractDcapMeasurement(cert)
+	if err != nil {
+		return types.EnclaveMeasurement{}, err
+	}
+	return types.EnclaveMeasurement{MrEnclave: mrEnclave, MrSigner: mrSigner, IsvProdId: prodID, IsvSvn: svn}, nil
+}
+
+// MockProvider skips hardware attestation entirely. It's only ever wired up on simulation
+// and test networks (chain param attestation_provider = "mock").
+type MockProvider struct{}
+
+func (MockProvider) ID() AttestationProviderID { return AttestationProviderMock }
+
+func (MockProvider) VerifyCertificate(cert ra.Certificate) ([]byte, error) {
+	// There's no enclave to vouch for, so derive the "public key" from the certificate
+	// itself - just enough to keep distinct nodes from colliding on the same store key.
+	sum := sha256.Sum256(cert)
+	return sum[:], nil
+}
+
+func (MockProvider) Measurement(cert ra.Certificate) (types.EnclaveMeasurement, error) {
+	// Mock networks don't enforce the enclave allow-list; return a placeholder measurement.
+	return types.EnclaveMeasurement{}, nil
+}
+
+// GetAttestationProvider resolves the provider selected in chain params to its implementation.
+func GetAttestationProvider(id AttestationProviderID, collateral DCAPCollateral) (AttestationProvider, error) {
+	switch id {
+	case AttestationProviderEPID:
+		return EPIDProvider{}, nil
+	case AttestationProviderDCAP:
+		return DCAPProvider{Collateral: collateral}, nil
+	case AttestationProviderMock:
+		return MockProvider{}, nil
+	default:
+		return nil, fmt.Errorf("unknown attestation provider %q", id)
+	}
+}
@@ -0,0 +1,55 @@
+package keeper
+
+import (
+	"testing"
+
+	ra "github.com/scrtlabs/SecretNetwork/x/registration/remote_attestation"
+)
+
+func TestMockProvider_VerifyCertificateDoesNotCollide(t *testing.T) {
+	certA := ra.Certificate("node-a-certificate")
+	certB := ra.Certificate("node-b-certificate")
+
+	keyA, err := MockProvider{}.VerifyCertificate(certA)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	keyB, err := MockProvider{}.VerifyCertificate(certB)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(keyA) == string(keyB) {
+		t.Fatal("distinct certificates must not resolve to the same mock public key")
+	}
+
+	keyAAgain, err := MockProvider{}.VerifyCertificate(certA)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(keyA) != string(keyAAgain) {
+		t.Fatal("the same certificate must resolve to the same mock public key every time")
+	}
+}
+
+func TestGetAttestationProvider(t *testing.T) {
+	tests := []struct {
+		id      AttestationProviderID
+		wantErr bool
+	}{
+		{AttestationProviderEPID, false},
+		{AttestationProviderDCAP, false},
+		{AttestationProviderMock, false},
+		{AttestationProviderID("bogus"), true},
+	}
+
+	for _, tc := range tests {
+		_, err := GetAttestationProvider(tc.id, DCAPCollateral{})
+		if tc.wantErr && err == nil {
+			t.Fatalf("expected an error for provider %q", tc.id)
+		}
+		if !tc.wantErr && err != nil {
+			t.Fatalf("unexpected error for provider %q: %v", tc.id, err)
+		}
+	}
+}
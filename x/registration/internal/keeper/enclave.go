@@ -0,0 +1,17 @@
+package keeper
+
+import (
+	ra "github.com/scrtlabs/SecretNetwork/x/registration/remote_attestation"
+)
+
+// EnclaveInterface is the Keeper's view of the go-cosmwasm enclave FFI boundary: loading
+// the consensus seed at startup and minting per-node encrypted seed copies on registration.
+type EnclaveInterface interface {
+	LoadSeed(masterKey []byte, encryptedSeed []byte, apiKey []byte) (bool, error)
+	GetEncryptedSeed(cert ra.Certificate) ([]byte, error)
+
+	// RotateSeed decrypts the seed generation at oldIdx, generates a new master key/seed
+	// pair, and returns that new master public key alongside the new seed encrypted to
+	// newCert's enclave.
+	RotateSeed(oldIdx uint32, newCert ra.Certificate) (newMasterKey []byte, newEncryptedSeed []byte, err error)
+}
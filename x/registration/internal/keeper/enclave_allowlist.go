@@ -0,0 +1,120 @@
+package keeper
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/scrtlabs/SecretNetwork/x/registration/internal/types"
+	ra "github.com/scrtlabs/SecretNetwork/x/registration/remote_attestation"
+)
+
+var enclaveAllowListPrefix = []byte{0x02}
+
+func enclaveAllowListKey(mrEnclave, mrSigner string) []byte {
+	return append(enclaveAllowListPrefix, []byte(mrEnclave+"/"+mrSigner)...)
+}
+
+// SetEnclaveMeasurement adds or updates an allow-listed enclave measurement.
+func (k Keeper) SetEnclaveMeasurement(ctx sdk.Context, m types.EnclaveMeasurement) {
+	bz, err := json.Marshal(m)
+	if err != nil {
+		panic(err)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(enclaveAllowListKey(m.MrEnclave, m.MrSigner), bz)
+}
+
+// GetEnclaveMeasurement looks up an allow-listed measurement by MRENCLAVE/MRSIGNER.
+func (k Keeper) GetEnclaveMeasurement(ctx sdk.Context, mrEnclave, mrSigner string) (types.EnclaveMeasurement, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(enclaveAllowListKey(mrEnclave, mrSigner))
+	if bz == nil {
+		return types.EnclaveMeasurement{}, false
+	}
+
+	var m types.EnclaveMeasurement
+	if err := json.Unmarshal(bz, &m); err != nil {
+		panic(err)
+	}
+
+	return m, true
+}
+
+// RetireEnclaveMeasurement marks a measurement expired as of the current height, rejecting
+// any registration attempt quoting it from this height onward.
+func (k Keeper) RetireEnclaveMeasurement(ctx sdk.Context, mrEnclave, mrSigner string) error {
+	m, ok := k.GetEnclaveMeasurement(ctx, mrEnclave, mrSigner)
+	if !ok {
+		return sdkerrors.Wrap(types.ErrUnknownEnclaveMeasurement, mrEnclave)
+	}
+
+	m.ExpiredAt = ctx.BlockHeight()
+	k.SetEnclaveMeasurement(ctx, m)
+
+	return nil
+}
+
+// IsMeasurementAllowed reports whether the given measurement is active: present in the
+// allow-list and not yet expired as of ctx's block height.
+func (k Keeper) IsMeasurementAllowed(ctx sdk.Context, mrEnclave, mrSigner string) bool {
+	m, ok := k.GetEnclaveMeasurement(ctx, mrEnclave, mrSigner)
+	if !ok {
+		return false
+	}
+
+	return m.ExpiredAt == 0 || m.ExpiredAt > ctx.BlockHeight()
+}
+
+// isEnclaveAllowed reports whether cert's enclave measurement is active in the
+// governance-controlled allow-list. MockProvider certificates bypass the check, since
+// there's no real enclave to measure on simulation/test networks.
+func (k Keeper) isEnclaveAllowed(ctx sdk.Context, cert ra.Certificate) bool {
+	if k.attestationProvider.ID() == AttestationProviderMock {
+		return true
+	}
+
+	m, err := k.attestationProvider.Measurement(cert)
+	if err != nil {
+		return false
+	}
+
+	allowed, ok := k.GetEnclaveMeasurement(ctx, m.MrEnclave, m.MrSigner)
+	if !ok || allowed.IsvProdId != m.IsvProdId || allowed.IsvSvn != m.IsvSvn {
+		return false
+	}
+
+	return k.IsMeasurementAllowed(ctx, m.MrEnclave, m.MrSigner)
+}
+
+// UpdateEnclaveAllowList applies a governance-approved MsgUpdateEnclaveAllowList: adding
+// newly-approved enclave measurements and retiring ones that should no longer validate new
+// registrations.
+func (k Keeper) UpdateEnclaveAllowList(ctx sdk.Context, msg types.MsgUpdateEnclaveAllowList) error {
+	for _, m := range msg.Add {
+		k.SetEnclaveMeasurement(ctx, m)
+
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeEnclaveAllowListUpdated,
+			sdk.NewAttribute(types.AttributeKeyMrEnclave, m.MrEnclave),
+			sdk.NewAttribute(types.AttributeKeyMrSigner, m.MrSigner),
+			sdk.NewAttribute(types.AttributeKeyAction, types.AttributeValueActionAdd),
+		))
+	}
+
+	for _, ref := range msg.Retire {
+		if err := k.RetireEnclaveMeasurement(ctx, ref.MrEnclave, ref.MrSigner); err != nil {
+			return err
+		}
+
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeEnclaveAllowListUpdated,
+			sdk.NewAttribute(types.AttributeKeyMrEnclave, ref.MrEnclave),
+			sdk.NewAttribute(types.AttributeKeyMrSigner, ref.MrSigner),
+			sdk.NewAttribute(types.AttributeKeyAction, types.AttributeValueActionRetire),
+		))
+	}
+
+	return nil
+}
@@ -22,19 +22,30 @@ type Keeper struct {
 	cdc      codec.BinaryCodec
 	enclave  EnclaveInterface
 	router   sdk.Router
+
+	// attestationProvider verifies the remote-attestation certificates nodes present to
+	// RegisterNode. It's resolved once at construction time from the chain's
+	// attestation_provider param so a single network can run EPID, DCAP or mock hardware.
+	attestationProvider AttestationProvider
+
+	// homeDir is the node's home directory, so ApplyPendingSeedRotation can find and rewrite
+	// the same on-disk seed config InitializeNode loaded at startup.
+	homeDir string
 }
 
 // NewKeeper creates a new contract Keeper instance
-func NewKeeper(cdc codec.BinaryCodec, storeKey sdk.StoreKey, router sdk.Router, enclave EnclaveInterface, homeDir string, bootstrap bool) Keeper {
+func NewKeeper(cdc codec.BinaryCodec, storeKey sdk.StoreKey, router sdk.Router, enclave EnclaveInterface, attestationProvider AttestationProvider, homeDir string, bootstrap bool) Keeper {
 	if !bootstrap {
 		InitializeNode(homeDir, enclave)
 	}
 
 	return Keeper{
-		storeKey: storeKey,
-		cdc:      cdc,
-		router:   router,
-		enclave:  enclave,
+		storeKey:            storeKey,
+		cdc:                 cdc,
+		router:              router,
+		enclave:             enclave,
+		attestationProvider: attestationProvider,
+		homeDir:             homeDir,
 	}
 }
 
@@ -97,12 +108,17 @@ func InitializeNode(homeDir string, enclave EnclaveInterface) {
 	copy(newEnc[1:], enc)
 
 	seedCfg.EncryptedKey = hex.EncodeToString(newEnc)
+	seedCfg.EnsureGenerations()
 
 	err = validateSeedParams(seedCfg)
 	if err != nil {
 		panic(sdkerrors.Wrap(types.ErrSeedInitFailed, err.Error()))
 	}
 
+	if err := migrateSeedConfigOnDisk(seedPath, seedCfg); err != nil {
+		panic(sdkerrors.Wrap(types.ErrSeedInitFailed, err.Error()))
+	}
+
 	_, err = enclave.LoadSeed(pk, newEnc, apiKey)
 	if err != nil {
 		panic(sdkerrors.Wrap(types.ErrSeedInitFailed, err.Error()))
@@ -118,7 +134,7 @@ func (k Keeper) RegisterNode(ctx sdk.Context, certificate ra.Certificate) ([]byt
 		encSeed = make([]byte, 32)
 	} else {
 
-		publicKey, err := ra.VerifyRaCert(certificate)
+		publicKey, err := k.attestationProvider.VerifyCertificate(certificate)
 		if err != nil {
 			return nil, sdkerrors.Wrap(types.ErrAuthenticateFailed, err.Error())
 		}
@@ -128,19 +144,31 @@ func (k Keeper) RegisterNode(ctx sdk.Context, certificate ra.Certificate) ([]byt
 			return nil, sdkerrors.Wrap(types.ErrAuthenticateFailed, err.Error())
 		}
 		if isAuth {
+			// Already-registered nodes are re-fetching their own stored seed, not attempting
+			// a fresh registration - don't re-check the allow-list, or retiring an MRENCLAVE
+			// via governance would lock out every node that was already trusted under it.
 			return k.getRegistrationInfo(ctx, publicKey).EncryptedSeed, nil
 		}
+
+		if !k.isEnclaveAllowed(ctx, certificate) {
+			return nil, sdkerrors.Wrap(types.ErrEnclaveNotAllowed, "enclave measurement is not in the active allow-list")
+		}
+
 		encSeed, err = k.enclave.GetEncryptedSeed(certificate)
 		if err != nil {
 			// return 0, sdkerrors.Wrap(err, "cosmwasm create")
 			return nil, sdkerrors.Wrap(types.ErrAuthenticateFailed, err.Error())
 		}
 	}
-	fmt.Println("Done RegisterNode")
-	fmt.Println("Got seed: ", hex.EncodeToString(encSeed))
+	ctx.Logger().Debug(
+		"registered node",
+		"encrypted_seed_len", len(encSeed),
+		"attestation_provider", k.attestationProvider.ID(),
+	)
 	regInfo := types.RegistrationNodeInfo{
-		Certificate:   certificate,
-		EncryptedSeed: encSeed,
+		Certificate:         certificate,
+		EncryptedSeed:       encSeed,
+		AttestationProvider: string(k.attestationProvider.ID()),
 	}
 	k.SetRegistrationInfo(ctx, regInfo)
 
@@ -194,19 +222,31 @@ func fetchPubKeyFromLegacyCert(cert []byte) (string, error) {
 	return base64.StdEncoding.EncodeToString(pk), nil
 }
 
+// FetchRawPubKeyFromLegacyCert extracts the enclave public key from a legacy seed config's
+// attestation certificate. Legacy seed files predate DCAP/mock attestation, so they're
+// always verified against the EPID provider regardless of the chain's configured provider.
 func FetchRawPubKeyFromLegacyCert(cert []byte) ([]byte, error) {
-	pk, err := ra.VerifyRaCert(cert)
-	if err != nil {
-		return nil, err
+	return EPIDProvider{}.VerifyCertificate(cert)
+}
+
+func validateSeedParams(config types.SeedConfig) error {
+	if err := validateEncryptedKeyLength(config.EncryptedKey); err != nil {
+		return err
 	}
 
-	return pk, nil
+	for _, gen := range config.Generations {
+		if err := validateEncryptedKeyLength(gen.EncryptedKey); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-func validateSeedParams(config types.SeedConfig) error {
-	lenKey := len(config.EncryptedKey) - 2
+func validateEncryptedKeyLength(encryptedKey string) error {
+	lenKey := len(encryptedKey) - 2
 
-	if (lenKey != types.EncryptedKeyLength && lenKey != types.LegacyEncryptedKeyLength) || !IsHexString(config.EncryptedKey) {
+	if (lenKey != types.EncryptedKeyLength && lenKey != types.LegacyEncryptedKeyLength) || !IsHexString(encryptedKey) {
 		return sdkerrors.Wrap(types.ErrSeedValidationParams, "Invalid parameter: `seed` in seed parameters. Did you initialize the node?")
 	}
 	return nil
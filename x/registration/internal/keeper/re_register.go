@@ -0,0 +1,48 @@
+package keeper
+
+import (
+	"encoding/hex"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/scrtlabs/SecretNetwork/x/registration/internal/types"
+)
+
+// ReRegisterNode lets a node present a certificate from a newer enclave build and register
+// it under the chain's current allow-list, the same way RegisterNode does for a cert it
+// hasn't seen before. A newer build's certificate verifies to a different enclave public
+// key than any prior one, so there is no prior RegistrationNodeInfo to carry forward here
+// (that's also why isNodeAuthenticated's fast path in RegisterNode never fires for it) -
+// this message exists to make that explicit and always re-check the allow-list, rather than
+// leaving operators to rely on RegisterNode's unauthenticated-cert path for upgrades. It
+// does not verify any continuity with a node's previous registration; nothing in the
+// certificate or this message ties the new enclave key back to the old one.
+func (k Keeper) ReRegisterNode(ctx sdk.Context, msg types.MsgReRegisterNode) ([]byte, error) {
+	publicKey, err := k.attestationProvider.VerifyCertificate(msg.Certificate)
+	if err != nil {
+		return nil, sdkerrors.Wrap(types.ErrAuthenticateFailed, err.Error())
+	}
+
+	if !k.isEnclaveAllowed(ctx, msg.Certificate) {
+		return nil, sdkerrors.Wrap(types.ErrEnclaveNotAllowed, "enclave measurement is not in the active allow-list")
+	}
+
+	encSeed, err := k.enclave.GetEncryptedSeed(msg.Certificate)
+	if err != nil {
+		return nil, sdkerrors.Wrap(types.ErrAuthenticateFailed, err.Error())
+	}
+
+	regInfo := types.RegistrationNodeInfo{
+		Certificate:         msg.Certificate,
+		EncryptedSeed:       encSeed,
+		AttestationProvider: string(k.attestationProvider.ID()),
+	}
+	k.SetRegistrationInfo(ctx, regInfo)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeNodeReRegistered,
+		sdk.NewAttribute(types.AttributeKeyPublicKey, hex.EncodeToString(publicKey)),
+	))
+
+	return encSeed, nil
+}
@@ -0,0 +1,121 @@
+package keeper
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/scrtlabs/SecretNetwork/x/registration/internal/types"
+	ra "github.com/scrtlabs/SecretNetwork/x/registration/remote_attestation"
+)
+
+var pendingSeedRotationKey = []byte{0x03}
+
+// migrateSeedConfigOnDisk rewrites seedPath with cfg's current, generation-aware encoding,
+// so a pre-rotation SeedConfig or LegacySeedConfig only ever needs to be upgraded once.
+func migrateSeedConfigOnDisk(seedPath string, cfg types.SeedConfig) error {
+	bz, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(seedPath, bz, 0o600)
+}
+
+// ScheduleSeedRotation persists a governance-approved MsgRotateConsensusSeed so the
+// module's EndBlocker can trigger the actual enclave-side rotation once the chain reaches
+// msg.TargetHeight.
+func (k Keeper) ScheduleSeedRotation(ctx sdk.Context, msg types.MsgRotateConsensusSeed) error {
+	if msg.TargetHeight <= ctx.BlockHeight() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "target_height must be in the future")
+	}
+
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(pendingSeedRotationKey, bz)
+
+	return nil
+}
+
+// GetPendingSeedRotation returns the currently scheduled rotation, if any.
+func (k Keeper) GetPendingSeedRotation(ctx sdk.Context) (types.MsgRotateConsensusSeed, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(pendingSeedRotationKey)
+	if bz == nil {
+		return types.MsgRotateConsensusSeed{}, false
+	}
+
+	var msg types.MsgRotateConsensusSeed
+	if err := json.Unmarshal(bz, &msg); err != nil {
+		panic(err)
+	}
+
+	return msg, true
+}
+
+// ApplyPendingSeedRotation is meant to run at EndBlock: once the chain reaches a scheduled
+// rotation's target height, this node re-seals the seed generation at the rotation's
+// OldSeedIdx into a new one via its local enclave, using cert to authorize the rotation, appends the result as a new
+// SeedGeneration in the on-disk seed config, and clears the pending rotation.
+func (k Keeper) ApplyPendingSeedRotation(ctx sdk.Context, cert ra.Certificate) error {
+	msg, ok := k.GetPendingSeedRotation(ctx)
+	if !ok || ctx.BlockHeight() < msg.TargetHeight {
+		return nil
+	}
+
+	newMasterKey, newEncSeed, err := k.enclave.RotateSeed(msg.OldSeedIdx, cert)
+	if err != nil {
+		return sdkerrors.Wrap(types.ErrSeedRotationFailed, err.Error())
+	}
+
+	seedPath := filepath.Join(k.homeDir, types.SecretNodeCfgFolder, types.SecretNodeSeedLegacyConfig)
+	byteValue, err := getFile(seedPath)
+	if err != nil {
+		return sdkerrors.Wrap(types.ErrSeedRotationFailed, err.Error())
+	}
+
+	var seedCfg types.SeedConfig
+	if err := json.Unmarshal(byteValue, &seedCfg); err != nil {
+		return sdkerrors.Wrap(types.ErrSeedRotationFailed, err.Error())
+	}
+
+	seedCfg = appendSeedGeneration(seedCfg, newMasterKey, newEncSeed)
+
+	if err := migrateSeedConfigOnDisk(seedPath, seedCfg); err != nil {
+		return sdkerrors.Wrap(types.ErrSeedRotationFailed, err.Error())
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(pendingSeedRotationKey)
+
+	return nil
+}
+
+// appendSeedGeneration records a freshly-rotated master key/seed pair as the new current
+// generation of cfg, preserving every prior generation so blocks sealed under them can
+// still be decrypted.
+func appendSeedGeneration(cfg types.SeedConfig, newMasterKey, newEncSeed []byte) types.SeedConfig {
+	cfg.EnsureGenerations()
+
+	newIdx := cfg.CurrentSeedIdx + 1
+	newGeneration := types.SeedGeneration{
+		Idx:          newIdx,
+		MasterKey:    base64.StdEncoding.EncodeToString(newMasterKey),
+		EncryptedKey: hex.EncodeToString(newEncSeed),
+	}
+
+	cfg.Generations = append(cfg.Generations, newGeneration)
+	cfg.CurrentSeedIdx = newIdx
+	cfg.MasterKey = newGeneration.MasterKey
+	cfg.EncryptedKey = newGeneration.EncryptedKey
+
+	return cfg
+}
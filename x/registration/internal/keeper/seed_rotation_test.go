@@ -0,0 +1,106 @@
+package keeper
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/scrtlabs/SecretNetwork/x/registration/internal/types"
+)
+
+func TestAppendSeedGeneration(t *testing.T) {
+	original := types.SeedConfig{
+		MasterKey:      base64.StdEncoding.EncodeToString([]byte("old-master-key")),
+		EncryptedKey:   hex.EncodeToString([]byte("old-encrypted-seed")),
+		CurrentSeedIdx: 0,
+	}
+
+	newMasterKey := []byte("new-master-key")
+	newEncSeed := []byte("new-encrypted-seed")
+
+	rotated := appendSeedGeneration(original, newMasterKey, newEncSeed)
+
+	if rotated.CurrentSeedIdx != 1 {
+		t.Fatalf("expected CurrentSeedIdx to advance to 1, got %d", rotated.CurrentSeedIdx)
+	}
+	if len(rotated.Generations) != 2 {
+		t.Fatalf("expected the old generation to be preserved alongside the new one, got %d generations", len(rotated.Generations))
+	}
+	if rotated.Generations[0].Idx != 0 || rotated.Generations[0].MasterKey != original.MasterKey {
+		t.Fatalf("old generation was not preserved: %+v", rotated.Generations[0])
+	}
+
+	gotMasterKey, err := base64.StdEncoding.DecodeString(rotated.Generations[1].MasterKey)
+	if err != nil {
+		t.Fatalf("new generation master key is not valid base64: %v", err)
+	}
+	if string(gotMasterKey) != string(newMasterKey) {
+		t.Fatalf("new generation master key = %q, want %q", gotMasterKey, newMasterKey)
+	}
+
+	gotEncSeed, err := hex.DecodeString(rotated.Generations[1].EncryptedKey)
+	if err != nil {
+		t.Fatalf("new generation encrypted key is not valid hex: %v", err)
+	}
+	if string(gotEncSeed) != string(newEncSeed) {
+		t.Fatalf("new generation encrypted key = %q, want %q", gotEncSeed, newEncSeed)
+	}
+
+	if rotated.MasterKey != rotated.Generations[1].MasterKey || rotated.EncryptedKey != rotated.Generations[1].EncryptedKey {
+		t.Fatal("top-level MasterKey/EncryptedKey must mirror the new current generation")
+	}
+}
+
+func TestAppendSeedGeneration_PersistsNewGenerationOnDisk(t *testing.T) {
+	dir, err := ioutil.TempDir("", "seed-rotation-test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	seedPath := filepath.Join(dir, "seed.json")
+	initial := types.SeedConfig{
+		MasterKey:      base64.StdEncoding.EncodeToString([]byte("old-master-key")),
+		EncryptedKey:   hex.EncodeToString([]byte("old-encrypted-seed")),
+		CurrentSeedIdx: 0,
+	}
+	if err := migrateSeedConfigOnDisk(seedPath, initial); err != nil {
+		t.Fatalf("failed to seed the on-disk config: %v", err)
+	}
+
+	byteValue, err := getFile(seedPath)
+	if err != nil {
+		t.Fatalf("failed to read back seed config: %v", err)
+	}
+
+	var reloaded types.SeedConfig
+	if err := json.Unmarshal(byteValue, &reloaded); err != nil {
+		t.Fatalf("failed to unmarshal seed config: %v", err)
+	}
+
+	rotated := appendSeedGeneration(reloaded, []byte("rotated-master-key"), []byte("rotated-encrypted-seed"))
+	if err := migrateSeedConfigOnDisk(seedPath, rotated); err != nil {
+		t.Fatalf("failed to persist rotated seed config: %v", err)
+	}
+
+	persisted, err := getFile(seedPath)
+	if err != nil {
+		t.Fatalf("failed to read back rotated seed config: %v", err)
+	}
+
+	var final types.SeedConfig
+	if err := json.Unmarshal(persisted, &final); err != nil {
+		t.Fatalf("failed to unmarshal rotated seed config: %v", err)
+	}
+
+	if final.CurrentSeedIdx != 1 {
+		t.Fatalf("expected persisted CurrentSeedIdx to be 1, got %d", final.CurrentSeedIdx)
+	}
+	if len(final.Generations) != 2 {
+		t.Fatalf("expected 2 generations to be persisted, got %d", len(final.Generations))
+	}
+}
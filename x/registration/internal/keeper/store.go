@@ -0,0 +1,69 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/scrtlabs/SecretNetwork/x/registration/internal/types"
+	ra "github.com/scrtlabs/SecretNetwork/x/registration/remote_attestation"
+)
+
+var registrationInfoPrefix = []byte{0x01}
+
+// registrationKey derives the store key for a registration record: the enclave public key
+// its certificate attests to, falling back to a hash of the certificate itself if it no
+// longer verifies under the chain's current attestation provider (e.g. simulation mode).
+func (k Keeper) registrationKey(cert ra.Certificate) []byte {
+	if pk, err := k.attestationProvider.VerifyCertificate(cert); err == nil {
+		return append(registrationInfoPrefix, pk...)
+	}
+
+	sum := sha256.Sum256(cert)
+	return append(registrationInfoPrefix, sum[:]...)
+}
+
+// SetRegistrationInfo persists a node's registration record, keyed by its enclave public key.
+func (k Keeper) SetRegistrationInfo(ctx sdk.Context, info types.RegistrationNodeInfo) {
+	bz, err := json.Marshal(info)
+	if err != nil {
+		panic(err)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(k.registrationKey(info.Certificate), bz)
+}
+
+// getRegistrationInfo looks up a previously persisted registration record by enclave public key.
+func (k Keeper) getRegistrationInfo(ctx sdk.Context, publicKey []byte) types.RegistrationNodeInfo {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(append(registrationInfoPrefix, publicKey...))
+
+	var info types.RegistrationNodeInfo
+	if bz == nil {
+		return info
+	}
+
+	if err := json.Unmarshal(bz, &info); err != nil {
+		panic(err)
+	}
+
+	return info
+}
+
+// isNodeAuthenticated reports whether publicKey already has a registration record.
+func (k Keeper) isNodeAuthenticated(ctx sdk.Context, publicKey []byte) (bool, error) {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(append(registrationInfoPrefix, publicKey...)), nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func getFile(path string) ([]byte, error) {
+	return ioutil.ReadFile(path)
+}
@@ -0,0 +1,19 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// ModuleCdc is the amino codec used to produce canonical SignBytes for registration messages.
+var ModuleCdc = codec.NewLegacyAmino()
+
+func init() {
+	RegisterLegacyAminoCodec(ModuleCdc)
+}
+
+// RegisterLegacyAminoCodec registers the registration module's messages for Amino JSON signing.
+func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	cdc.RegisterConcrete(MsgUpdateEnclaveAllowList{}, "registration/MsgUpdateEnclaveAllowList", nil)
+	cdc.RegisterConcrete(MsgReRegisterNode{}, "registration/MsgReRegisterNode", nil)
+	cdc.RegisterConcrete(MsgRotateConsensusSeed{}, "registration/MsgRotateConsensusSeed", nil)
+}
@@ -0,0 +1,21 @@
+package types
+
+// EnclaveMeasurement identifies a specific enclave build/signer/version combination that's
+// permitted to register with the network.
+type EnclaveMeasurement struct {
+	MrEnclave string `json:"mr_enclave"`
+	MrSigner  string `json:"mr_signer"`
+	IsvProdId uint16 `json:"isv_prod_id"`
+	IsvSvn    uint16 `json:"isv_svn"`
+
+	// ExpiredAt is the block height from which on this measurement is no longer accepted for
+	// new registrations, or 0 if it's still active.
+	ExpiredAt int64 `json:"expired_at"`
+}
+
+// EnclaveRef identifies an enclave measurement by its MRENCLAVE/MRSIGNER pair - enough to
+// look one up in the allow-list for retirement.
+type EnclaveRef struct {
+	MrEnclave string `json:"mr_enclave"`
+	MrSigner  string `json:"mr_signer"`
+}
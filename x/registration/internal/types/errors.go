@@ -0,0 +1,15 @@
+package types
+
+import (
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// x/registration module sentinel errors.
+var (
+	ErrSeedInitFailed            = sdkerrors.Register(ModuleName, 2, "failed to initialize node seed")
+	ErrAuthenticateFailed        = sdkerrors.Register(ModuleName, 3, "failed to authenticate node")
+	ErrSeedValidationParams      = sdkerrors.Register(ModuleName, 4, "failed to validate seed parameters")
+	ErrEnclaveNotAllowed         = sdkerrors.Register(ModuleName, 5, "enclave measurement is not allow-listed")
+	ErrUnknownEnclaveMeasurement = sdkerrors.Register(ModuleName, 6, "enclave measurement not found in allow-list")
+	ErrSeedRotationFailed        = sdkerrors.Register(ModuleName, 7, "failed to rotate consensus seed")
+)
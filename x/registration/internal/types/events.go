@@ -0,0 +1,16 @@
+package types
+
+// Event types and attribute keys emitted by the registration module, so light clients can
+// track enclave allow-list changes and node re-registrations without replaying state.
+const (
+	EventTypeEnclaveAllowListUpdated = "enclave_allow_list_updated"
+	EventTypeNodeReRegistered        = "node_re_registered"
+
+	AttributeKeyMrEnclave = "mr_enclave"
+	AttributeKeyMrSigner  = "mr_signer"
+	AttributeKeyAction    = "action"
+	AttributeKeyPublicKey = "public_key"
+
+	AttributeValueActionAdd    = "add"
+	AttributeValueActionRetire = "retire"
+)
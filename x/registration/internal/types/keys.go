@@ -0,0 +1,15 @@
+package types
+
+const (
+	// ModuleName is the name of the registration module
+	ModuleName = "registration"
+
+	// StoreKey is the default store key for registration
+	StoreKey = ModuleName
+
+	// QuerierRoute is the querier route for registration
+	QuerierRoute = ModuleName
+
+	// RouterKey is the message route for registration
+	RouterKey = ModuleName
+)
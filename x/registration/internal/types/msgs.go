@@ -0,0 +1,116 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	ra "github.com/scrtlabs/SecretNetwork/x/registration/remote_attestation"
+)
+
+const (
+	TypeMsgUpdateEnclaveAllowList = "update_enclave_allow_list"
+	TypeMsgReRegisterNode         = "re_register_node"
+	TypeMsgRotateConsensusSeed    = "rotate_consensus_seed"
+)
+
+// MsgUpdateEnclaveAllowList is a governance proposal message that adds newly-approved
+// enclave measurements to the chain's allow-list and/or retires ones that should no longer
+// validate new registrations.
+type MsgUpdateEnclaveAllowList struct {
+	Authority string               `json:"authority"`
+	Add       []EnclaveMeasurement `json:"add"`
+	Retire    []EnclaveRef         `json:"retire"`
+}
+
+func (msg MsgUpdateEnclaveAllowList) Route() string { return RouterKey }
+func (msg MsgUpdateEnclaveAllowList) Type() string  { return TypeMsgUpdateEnclaveAllowList }
+
+func (msg MsgUpdateEnclaveAllowList) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+	if len(msg.Add) == 0 && len(msg.Retire) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "must add or retire at least one measurement")
+	}
+	return nil
+}
+
+func (msg MsgUpdateEnclaveAllowList) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgUpdateEnclaveAllowList) GetSigners() []sdk.AccAddress {
+	authority, err := sdk.AccAddressFromBech32(msg.Authority)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{authority}
+}
+
+// MsgReRegisterNode lets a node register the certificate of a newer enclave build against
+// the chain's current allow-list. It does not establish any continuity with a node's prior
+// registration - a new build's certificate verifies to a new enclave public key, so there's
+// no previous RegistrationNodeInfo to carry forward - it's an explicit, allow-list-checked
+// re-registration, not an upgrade path that preserves node identity.
+type MsgReRegisterNode struct {
+	Sender      string         `json:"sender"`
+	Certificate ra.Certificate `json:"certificate"`
+}
+
+func (msg MsgReRegisterNode) Route() string { return RouterKey }
+func (msg MsgReRegisterNode) Type() string  { return TypeMsgReRegisterNode }
+
+func (msg MsgReRegisterNode) ValidateBasic() error {
+	if len(msg.Certificate) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "certificate cannot be empty")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+	return nil
+}
+
+func (msg MsgReRegisterNode) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgReRegisterNode) GetSigners() []sdk.AccAddress {
+	sender, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{sender}
+}
+
+// MsgRotateConsensusSeed is a governance proposal message that schedules a network-wide
+// consensus seed rotation: every node re-seals the seed generation at OldSeedIdx into a
+// new generation once the chain reaches TargetHeight.
+type MsgRotateConsensusSeed struct {
+	Authority    string `json:"authority"`
+	OldSeedIdx   uint32 `json:"old_seed_idx"`
+	TargetHeight int64  `json:"target_height"`
+}
+
+func (msg MsgRotateConsensusSeed) Route() string { return RouterKey }
+func (msg MsgRotateConsensusSeed) Type() string  { return TypeMsgRotateConsensusSeed }
+
+func (msg MsgRotateConsensusSeed) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+	if msg.TargetHeight <= 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "target_height must be positive")
+	}
+	return nil
+}
+
+func (msg MsgRotateConsensusSeed) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgRotateConsensusSeed) GetSigners() []sdk.AccAddress {
+	authority, err := sdk.AccAddressFromBech32(msg.Authority)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{authority}
+}
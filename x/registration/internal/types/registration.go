@@ -0,0 +1,17 @@
+package types
+
+import (
+	ra "github.com/scrtlabs/SecretNetwork/x/registration/remote_attestation"
+)
+
+// RegistrationNodeInfo is the persisted record created the first time a node successfully
+// proves it is running inside an authorized enclave.
+type RegistrationNodeInfo struct {
+	Certificate   ra.Certificate `json:"certificate"`
+	EncryptedSeed []byte         `json:"encrypted_seed"`
+
+	// AttestationProvider is the identifier of the AttestationProvider that verified
+	// Certificate, so mixed-attestation networks can re-verify historical registrations
+	// against the scheme that originally vouched for them.
+	AttestationProvider string `json:"attestation_provider"`
+}
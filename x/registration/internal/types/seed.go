@@ -0,0 +1,101 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
+)
+
+const (
+	// SecretNodeCfgFolder is the directory under the node's home dir holding seed config.
+	SecretNodeCfgFolder = ".node"
+	// SecretNodeSeedConfig is the current seed config file name.
+	SecretNodeSeedConfig = "seed.json"
+	// SecretNodeSeedLegacyConfig is the seed config file name used pre-upgrade.
+	SecretNodeSeedLegacyConfig = "seed.json"
+
+	// EncryptedKeyLength is the hex-encoded length of a seed encrypted under the current scheme.
+	EncryptedKeyLength = 96
+	// LegacyEncryptedKeyLength is the hex-encoded length of a seed encrypted under the pre-upgrade scheme.
+	LegacyEncryptedKeyLength = 64
+
+	apiKeyFile = "api_key.txt"
+)
+
+// SeedConfig is the on-disk representation of the consensus seed: the enclave's master
+// public key alongside the seed encrypted to it. MasterKey/EncryptedKey always mirror the
+// generation at CurrentSeedIdx, so a config with a single, never-rotated generation decodes
+// exactly as it did before seed rotation existed.
+type SeedConfig struct {
+	MasterKey    string `json:"master_key"`
+	EncryptedKey string `json:"encrypted_key"`
+
+	// CurrentSeedIdx is the index into Generations of the seed currently loaded into the
+	// enclave.
+	CurrentSeedIdx uint32 `json:"current_seed_idx"`
+	// Generations holds every seed generation the network has ever rotated through, oldest
+	// first, so historical blocks sealed under a retired seed can still be decrypted.
+	Generations []SeedGeneration `json:"generations,omitempty"`
+}
+
+// SeedGeneration is one historical consensus seed: the master key it was encrypted to and
+// the seed ciphertext itself.
+type SeedGeneration struct {
+	Idx          uint32 `json:"idx"`
+	MasterKey    string `json:"master_key"`
+	EncryptedKey string `json:"encrypted_key"`
+}
+
+// Decode returns the raw master public key and the raw encrypted seed bytes.
+func (cfg SeedConfig) Decode() ([]byte, []byte, error) {
+	pk, err := base64.StdEncoding.DecodeString(cfg.MasterKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	enc, err := hex.DecodeString(cfg.EncryptedKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pk, enc, nil
+}
+
+// EnsureGenerations backfills Generations from the legacy single-key fields the first time
+// a pre-rotation seed config is loaded, so every SeedConfig can be treated uniformly as a
+// list of generations from here on.
+func (cfg *SeedConfig) EnsureGenerations() {
+	if len(cfg.Generations) > 0 {
+		return
+	}
+
+	cfg.CurrentSeedIdx = 0
+	cfg.Generations = []SeedGeneration{{Idx: 0, MasterKey: cfg.MasterKey, EncryptedKey: cfg.EncryptedKey}}
+}
+
+// LegacySeedConfig is the seed file format used before master keys were stored as raw
+// base64 public keys; it carried the full attestation certificate instead.
+type LegacySeedConfig struct {
+	MasterCert   string `json:"master_cert"`
+	EncryptedKey string `json:"encrypted_key"`
+}
+
+// Decode returns the raw attestation certificate and the raw encrypted seed bytes.
+func (cfg LegacySeedConfig) Decode() ([]byte, []byte, error) {
+	cert, err := base64.StdEncoding.DecodeString(cfg.MasterCert)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	enc, err := hex.DecodeString(cfg.EncryptedKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cert, enc, nil
+}
+
+// GetApiKey reads the Intel IAS API key used for EPID attestation.
+func GetApiKey() ([]byte, error) {
+	return ioutil.ReadFile(apiKeyFile)
+}
@@ -0,0 +1,213 @@
+package remote_attestation
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+)
+
+// reportDataOffset is the byte offset of the SGX quote's REPORT_BODY.reportdata field
+// within the overall DCAP quote structure (quote header + report body up to reportdata).
+const reportDataOffset = 368
+
+// quoteHeaderLen and reportBodyLen are the fixed sizes of the sgx_quote3_t header and the
+// REPORT_BODY it's immediately followed by, per the Intel DCAP quote format.
+const (
+	quoteHeaderLen = 48
+	reportBodyLen  = 384
+	quoteBodyLen   = quoteHeaderLen + reportBodyLen
+
+	ecdsaSigLen       = 64
+	ecdsaPubKeyLen    = 64
+	qeAuthDataLenSize = 2
+	qeCertDataHdrLen  = 2 + 4 // cert data type (u16) + cert data size (u32)
+)
+
+// tcbCollateral is the subset of Intel's TCB info / QE identity collateral schema this
+// cross-check reads: the tcbStatus field. checkTcbCollateral rejects only a "Revoked"
+// status; any other status, including "OutOfDate", is accepted.
+type tcbCollateral struct {
+	TCBInfo struct {
+		TCBStatus string `json:"tcbStatus"`
+	} `json:"tcbInfo"`
+}
+
+type qeIdentityCollateral struct {
+	EnclaveIdentity struct {
+		TCBStatus string `json:"tcbStatus"`
+	} `json:"enclaveIdentity"`
+}
+
+// VerifyDcapQuote verifies an Intel SGX DCAP (ECDSA) quote against the pinned PCCS
+// collateral - TCB info, QE identity and the PCK CRL chain - and returns the 32-byte
+// enclave public key SecretNetwork enclaves embed in the quote's reportdata.
+//
+// It checks: the quote's ECDSA signature over the quote body verifies under the
+// attestation key embedded in the signature section; the QE report binds to that same
+// attestation key; the QE report's own signature verifies under the PCK certificate
+// shipped in the quote's certification data; that PCK certificate isn't present in the
+// pinned CRL; and that the pinned TCB info / QE identity collateral don't flag the
+// hardware as revoked.
+func VerifyDcapQuote(quote Certificate, tcbInfo, qeIdentity, pckCrlChain []byte) ([]byte, error) {
+	if len(tcbInfo) == 0 || len(qeIdentity) == 0 {
+		return nil, fmt.Errorf("missing DCAP collateral")
+	}
+
+	if len(quote) < quoteBodyLen+4 {
+		return nil, fmt.Errorf("DCAP quote too short to contain a report body")
+	}
+
+	body := quote[:quoteBodyLen]
+	sigLen := binary.LittleEndian.Uint32(quote[quoteBodyLen : quoteBodyLen+4])
+	sigData := quote[quoteBodyLen+4:]
+	if uint32(len(sigData)) < sigLen {
+		return nil, fmt.Errorf("DCAP quote signature section shorter than its declared length")
+	}
+	sigData = sigData[:sigLen]
+
+	minSigData := ecdsaSigLen + ecdsaPubKeyLen + reportBodyLen + ecdsaSigLen + qeAuthDataLenSize
+	if len(sigData) < minSigData {
+		return nil, fmt.Errorf("DCAP quote signature section too short")
+	}
+
+	quoteSig := sigData[:ecdsaSigLen]
+	attestationKey := sigData[ecdsaSigLen : ecdsaSigLen+ecdsaPubKeyLen]
+	qeReport := sigData[ecdsaSigLen+ecdsaPubKeyLen : ecdsaSigLen+ecdsaPubKeyLen+reportBodyLen]
+	off := ecdsaSigLen + ecdsaPubKeyLen + reportBodyLen
+	qeReportSig := sigData[off : off+ecdsaSigLen]
+	off += ecdsaSigLen
+
+	qeAuthDataLen := binary.LittleEndian.Uint16(sigData[off : off+qeAuthDataLenSize])
+	off += qeAuthDataLenSize
+	if len(sigData) < off+int(qeAuthDataLen)+qeCertDataHdrLen {
+		return nil, fmt.Errorf("DCAP quote signature section truncated in QE auth/cert data")
+	}
+	qeAuthData := sigData[off : off+int(qeAuthDataLen)]
+	off += int(qeAuthDataLen)
+
+	off += 2 // cert data type, unused - we only support the PEM chain layout below
+	certDataLen := binary.LittleEndian.Uint32(sigData[off : off+4])
+	off += 4
+	if uint32(len(sigData)-off) < certDataLen {
+		return nil, fmt.Errorf("DCAP quote certification data shorter than its declared length")
+	}
+	pckChainPEM := sigData[off : off+int(certDataLen)]
+
+	pubKey, err := unmarshalP256PublicKey(attestationKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attestation key in DCAP quote: %w", err)
+	}
+
+	bodyHash := sha256.Sum256(body)
+	if !verifyRawECDSA(pubKey, bodyHash[:], quoteSig) {
+		return nil, fmt.Errorf("DCAP quote signature does not verify against its attestation key")
+	}
+
+	if len(qeReport) < reportDataOffset-quoteHeaderLen+32 {
+		return nil, fmt.Errorf("DCAP QE report too short")
+	}
+	qeReportData := qeReport[reportDataOffset-quoteHeaderLen : reportDataOffset-quoteHeaderLen+32]
+	expected := sha256.Sum256(append(append([]byte{}, attestationKey...), qeAuthData...))
+	if !bytes.Equal(qeReportData, expected[:]) {
+		return nil, fmt.Errorf("DCAP QE report is not bound to the quote's attestation key")
+	}
+
+	pckLeaf, err := leafCertFromPEMChain(pckChainPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PCK certificate chain in DCAP quote: %w", err)
+	}
+	if certRevoked(pckLeaf, pckCrlChain) {
+		return nil, fmt.Errorf("DCAP PCK certificate is present in the pinned CRL")
+	}
+	pckPubKey, ok := pckLeaf.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("DCAP PCK certificate does not carry an ECDSA public key")
+	}
+
+	qeReportHash := sha256.Sum256(qeReport)
+	if !verifyRawECDSA(pckPubKey, qeReportHash[:], qeReportSig) {
+		return nil, fmt.Errorf("DCAP QE report signature does not verify against the PCK certificate")
+	}
+
+	if err := checkTcbCollateral(tcbInfo, qeIdentity); err != nil {
+		return nil, err
+	}
+
+	return quote[reportDataOffset : reportDataOffset+32], nil
+}
+
+// unmarshalP256PublicKey builds an ECDSA P-256 public key out of the raw 64-byte x||y
+// encoding Intel's DCAP quote format uses for its attestation/PCK keys.
+func unmarshalP256PublicKey(raw []byte) (*ecdsa.PublicKey, error) {
+	if len(raw) != ecdsaPubKeyLen {
+		return nil, fmt.Errorf("expected a %d-byte uncompressed EC point, got %d", ecdsaPubKeyLen, len(raw))
+	}
+	x := new(big.Int).SetBytes(raw[:32])
+	y := new(big.Int).SetBytes(raw[32:])
+	curve := elliptic.P256()
+	if !curve.IsOnCurve(x, y) {
+		return nil, fmt.Errorf("attestation key is not a valid point on P-256")
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+// verifyRawECDSA verifies a 64-byte r||s ECDSA-P256 signature, the encoding Intel's DCAP
+// structures use in place of ASN.1 DER.
+func verifyRawECDSA(pub *ecdsa.PublicKey, hash, sig []byte) bool {
+	if len(sig) != ecdsaSigLen {
+		return false
+	}
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:])
+	return ecdsa.Verify(pub, hash, r, s)
+}
+
+// leafCertFromPEMChain parses the first PEM-encoded certificate out of a PCK certificate
+// chain, which is the leaf (PCK) certificate by Intel's convention.
+func leafCertFromPEMChain(chain []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(chain)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in certification data")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// certRevoked reports whether cert's serial number appears in the pinned CRL blob. The CRL
+// is treated as an opaque bundle of serial numbers rather than parsed as a full RFC 5280
+// CRL, matching the pinned-collateral model used for tcbInfo/qeIdentity below.
+func certRevoked(cert *x509.Certificate, crl []byte) bool {
+	if len(crl) == 0 {
+		return false
+	}
+	serial := []byte(cert.SerialNumber.Text(16))
+	return bytes.Contains(bytes.ToLower(crl), bytes.ToLower(serial))
+}
+
+// checkTcbCollateral rejects quotes whose pinned TCB info or QE identity collateral marks
+// the platform as revoked.
+func checkTcbCollateral(tcbInfo, qeIdentity []byte) error {
+	var tcb tcbCollateral
+	if err := json.Unmarshal(tcbInfo, &tcb); err != nil {
+		return fmt.Errorf("malformed tcbInfo collateral: %w", err)
+	}
+	if tcb.TCBInfo.TCBStatus == "Revoked" {
+		return fmt.Errorf("DCAP platform TCB status is Revoked")
+	}
+
+	var qe qeIdentityCollateral
+	if err := json.Unmarshal(qeIdentity, &qe); err != nil {
+		return fmt.Errorf("malformed qeIdentity collateral: %w", err)
+	}
+	if qe.EnclaveIdentity.TCBStatus == "Revoked" {
+		return fmt.Errorf("DCAP QE identity TCB status is Revoked")
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package remote_attestation
+
+import (
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+)
+
+// iasQuoteBodyOID is the custom X.509 extension OID Intel's IAS embeds the raw SGX quote
+// body under, alongside the report extension VerifyRaCert reads the public key from.
+var iasQuoteBodyOID = []int{1, 2, 840, 113741, 1, 1, 667}
+
+// Offsets of the fields read out of an sgx_quote_t's REPORT_BODY, relative to the start of
+// the quote: a 48-byte QUOTE_HEADER followed by the REPORT_BODY.
+const (
+	mrEnclaveOffset = 112
+	mrSignerOffset  = 176
+	isvProdIDOffset = 304
+	isvSvnOffset    = 306
+)
+
+// ExtractEpidMeasurement reads the enclave's MRENCLAVE/MRSIGNER/ISV product ID/SVN out of
+// an EPID attestation certificate's embedded quote body.
+func ExtractEpidMeasurement(cert Certificate) (mrEnclave, mrSigner string, isvProdID, isvSvn uint16, err error) {
+	parsed, err := x509.ParseCertificate(cert)
+	if err != nil {
+		return "", "", 0, 0, fmt.Errorf("failed to parse attestation certificate: %w", err)
+	}
+
+	for _, ext := range parsed.Extensions {
+		if ext.Id.Equal(iasQuoteBodyOID) {
+			return extractMeasurement(ext.Value)
+		}
+	}
+
+	return "", "", 0, 0, fmt.Errorf("attestation certificate is missing the IAS quote body extension")
+}
+
+// ExtractDcapMeasurement reads the enclave's MRENCLAVE/MRSIGNER/ISV product ID/SVN out of a
+// DCAP quote's report body.
+func ExtractDcapMeasurement(quote Certificate) (mrEnclave, mrSigner string, isvProdID, isvSvn uint16, err error) {
+	return extractMeasurement(quote)
+}
+
+func extractMeasurement(quote []byte) (mrEnclave, mrSigner string, isvProdID, isvSvn uint16, err error) {
+	if len(quote) < isvSvnOffset+2 {
+		return "", "", 0, 0, fmt.Errorf("quote too short to contain a report body")
+	}
+
+	mrEnclave = hex.EncodeToString(quote[mrEnclaveOffset : mrEnclaveOffset+32])
+	mrSigner = hex.EncodeToString(quote[mrSignerOffset : mrSignerOffset+32])
+	isvProdID = binary.LittleEndian.Uint16(quote[isvProdIDOffset : isvProdIDOffset+2])
+	isvSvn = binary.LittleEndian.Uint16(quote[isvSvnOffset : isvSvnOffset+2])
+
+	return mrEnclave, mrSigner, isvProdID, isvSvn, nil
+}
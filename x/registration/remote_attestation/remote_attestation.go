@@ -0,0 +1,33 @@
+package remote_attestation
+
+import (
+	"crypto/x509"
+	"fmt"
+)
+
+// Certificate is a raw remote-attestation certificate/report as produced by the enclave,
+// in whichever encoding the attestation provider that issued it expects.
+type Certificate []byte
+
+// iasReportOID is the custom X.509 extension OID Intel's IAS embeds its attestation
+// report under.
+var iasReportOID = []int{1, 2, 840, 113741, 1, 1, 666}
+
+// VerifyRaCert parses an Intel EPID (IAS) remote attestation certificate and returns the
+// enclave's public key embedded in its IAS report extension. It checks only that the
+// certificate parses as X.509 and carries that extension; the certificate's signature,
+// its chain to Intel's root and the report's contents are not verified.
+func VerifyRaCert(cert Certificate) ([]byte, error) {
+	parsed, err := x509.ParseCertificate(cert)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse attestation certificate: %w", err)
+	}
+
+	for _, ext := range parsed.Extensions {
+		if ext.Id.Equal(iasReportOID) {
+			return ext.Value, nil
+		}
+	}
+
+	return nil, fmt.Errorf("attestation certificate is missing the IAS report extension")
+}